@@ -0,0 +1,305 @@
+package gowork
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"sort"
+	"sync"
+)
+
+// FileScheme is the scheme the legacy GOPATH/src backend is registered
+// under.
+const FileScheme = "file"
+
+// ModScheme is the scheme the GOPATH/pkg/mod backend is registered under.
+const ModScheme = "mod"
+
+// A DistributorBackend knows how to discover authors and projects stored in
+// one particular place: the legacy GOPATH/src tree, the module cache, a
+// private index server, a monorepo layout, a go.work file, and so on.
+// Register one with RegisterBackend to make gowork aware of it.
+type DistributorBackend interface {
+	// Authors returns every Author this backend knows about.
+	Authors() ([]Author, error)
+
+	// Projects returns every Project this backend knows author has.
+	Projects(Author) ([]Project, error)
+
+	// AbsPath returns the absolute path this backend resolves project to,
+	// or "" if the backend has never heard of project.
+	AbsPath(Project) string
+}
+
+var (
+	backendsMu sync.RWMutex
+	backends   = map[string]DistributorBackend{}
+)
+
+// RegisterBackend makes a DistributorBackend available under scheme (e.g.
+// "file" or "mod"), following the pattern go-git's transport package uses
+// for its InstallProtocol registry. It is meant to be called from init() and
+// panics if scheme is already registered.
+func RegisterBackend(scheme string, b DistributorBackend) {
+	backendsMu.Lock()
+	defer backendsMu.Unlock()
+
+	if _, ok := backends[scheme]; ok {
+		panic(fmt.Sprintf("gowork: backend already registered for scheme %q", scheme))
+	}
+
+	backends[scheme] = b
+}
+
+// Backends returns the scheme of every registered DistributorBackend,
+// sorted with FileScheme always first since it's queried first too.
+func Backends() []string {
+	backendsMu.RLock()
+	defer backendsMu.RUnlock()
+
+	var schemes []string
+	for scheme := range backends {
+		if scheme != FileScheme {
+			schemes = append(schemes, scheme)
+		}
+	}
+
+	sort.Strings(schemes)
+
+	if _, ok := backends[FileScheme]; ok {
+		schemes = append([]string{FileScheme}, schemes...)
+	}
+
+	return schemes
+}
+
+// backend looks up a registered backend by its scheme.
+func backend(scheme string) (DistributorBackend, bool) {
+	backendsMu.RLock()
+	defer backendsMu.RUnlock()
+
+	b, ok := backends[scheme]
+	return b, ok
+}
+
+// allAuthors returns every Author known to the given backend schemes,
+// de-duplicated and sorted. A nil or empty schemes queries every registered
+// backend (see Backends). Every backend's error is best-effort: it's logged
+// and that backend is skipped, so e.g. a GOPATH with no src/ tree doesn't
+// keep the module cache from being reported. An error is only returned if
+// every queried backend failed.
+func allAuthors(schemes []string) ([]Author, error) {
+	if len(schemes) == 0 {
+		schemes = Backends()
+	}
+
+	seen := make(map[Author]bool)
+	var authors []Author
+	var errs []error
+	for _, scheme := range schemes {
+		b, ok := backend(scheme)
+		if !ok {
+			return nil, fmt.Errorf("gowork: no backend registered for scheme %q", scheme)
+		}
+
+		theseAuthors, err := b.Authors()
+		if err != nil {
+			log.Debug("Backend %q unavailable, skipping: %v", scheme, err)
+			errs = append(errs, fmt.Errorf("%s: %w", scheme, err))
+			continue
+		}
+
+		for _, a := range theseAuthors {
+			if !seen[a] {
+				seen[a] = true
+				authors = append(authors, a)
+			}
+		}
+	}
+
+	if len(errs) == len(schemes) && len(errs) > 0 {
+		return nil, errs[0]
+	}
+
+	sort.Slice(authors, func(i, j int) bool { return authors[i] < authors[j] })
+	return authors, nil
+}
+
+// projectsIn returns every Project the given backend schemes know author
+// has, de-duplicated and sorted. It follows the same best-effort error rules
+// as allAuthors.
+func projectsIn(schemes []string, a Author) ([]Project, error) {
+	if len(schemes) == 0 {
+		schemes = Backends()
+	}
+
+	seen := make(map[Project]bool)
+	var projects []Project
+	var errs []error
+	for _, scheme := range schemes {
+		b, ok := backend(scheme)
+		if !ok {
+			return nil, fmt.Errorf("gowork: no backend registered for scheme %q", scheme)
+		}
+
+		theseProjects, err := b.Projects(a)
+		if err != nil {
+			log.Debug("Backend %q unavailable for %v, skipping: %v", scheme, a, err)
+			errs = append(errs, fmt.Errorf("%s: %w", scheme, err))
+			continue
+		}
+
+		for _, p := range theseProjects {
+			if !seen[p] {
+				seen[p] = true
+				projects = append(projects, p)
+			}
+		}
+	}
+
+	if len(errs) == len(schemes) && len(errs) > 0 {
+		return nil, errs[0]
+	}
+
+	sort.Slice(projects, func(i, j int) bool { return projects[i] < projects[j] })
+	return projects, nil
+}
+
+// fileBackend is the default DistributorBackend, reproducing gowork's
+// original GOPATH/src behavior.
+type fileBackend struct{}
+
+func (fileBackend) Authors() ([]Author, error) {
+	entries := getGopathEntries()
+
+	seen := make(map[Author]bool)
+	var authors []Author
+	var foundAny bool
+	var lastErr error
+	for _, entry := range entries {
+		distroDirs, err := ioutil.ReadDir(path.Join(entry, "src"))
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		for _, distroDir := range distroDirs {
+			if !isProperDirectory(distroDir) {
+				continue
+			}
+			distro := Distributor(distroDir.Name())
+
+			authorDirs, err := ioutil.ReadDir(path.Join(entry, "src", distro.Name()))
+			if err != nil {
+				lastErr = err
+				continue
+			}
+
+			for _, authorDir := range authorDirs {
+				if !isProperDirectory(authorDir) {
+					continue
+				}
+
+				author := NewAuthor(distro, authorDir.Name())
+				if !seen[author] {
+					seen[author] = true
+					authors = append(authors, author)
+				}
+			}
+		}
+
+		foundAny = true
+	}
+
+	if !foundAny && lastErr != nil {
+		return nil, lastErr
+	}
+
+	return authors, nil
+}
+
+func (fileBackend) Projects(a Author) ([]Project, error) {
+	distro, name := a.Split()
+
+	seen := make(map[Project]bool)
+	var projects []Project
+	var foundAny bool
+	var lastErr error
+	for _, entry := range getGopathEntries() {
+		dir := path.Join(entry, "src", distro.Name(), name)
+		dirs, err := ioutil.ReadDir(dir)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		foundAny = true
+		for _, theDir := range dirs {
+			if !isProperDirectory(theDir) {
+				continue
+			}
+
+			project := NewProject(a, theDir.Name())
+			if !seen[project] {
+				seen[project] = true
+				projects = append(projects, project)
+			}
+		}
+	}
+
+	if !foundAny && lastErr != nil {
+		return nil, lastErr
+	}
+
+	return projects, nil
+}
+
+func (fileBackend) AbsPath(p Project) string {
+	abs := p.AbsPath()
+	if _, err := os.Stat(abs); err != nil {
+		return ""
+	}
+
+	return abs
+}
+
+// modBackend is the DistributorBackend for GOPATH/pkg/mod (see modcache.go).
+type modBackend struct{}
+
+func (modBackend) Authors() ([]Author, error) {
+	distros, err := modCacheDistributors()
+	if err != nil {
+		return nil, err
+	}
+
+	var authors []Author
+	for _, distro := range distros {
+		distroAuthors, err := modCacheAuthors(distro)
+		if err != nil {
+			return nil, err
+		}
+
+		authors = append(authors, distroAuthors...)
+	}
+
+	return authors, nil
+}
+
+func (modBackend) Projects(a Author) ([]Project, error) {
+	return modCacheProjects(a)
+}
+
+func (modBackend) AbsPath(p Project) string {
+	_, absPath, ok := modCacheLookup(p)
+	if !ok {
+		return ""
+	}
+
+	return absPath
+}
+
+func init() {
+	RegisterBackend(FileScheme, fileBackend{})
+	RegisterBackend(ModScheme, modBackend{})
+}
@@ -0,0 +1,103 @@
+package gowork
+
+import (
+	"errors"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubBackend struct {
+	err error
+}
+
+func (s stubBackend) Authors() ([]Author, error) {
+	return nil, s.err
+}
+
+func (s stubBackend) Projects(Author) ([]Project, error) {
+	return nil, s.err
+}
+
+func (stubBackend) AbsPath(Project) string {
+	return ""
+}
+
+// registerTestBackend registers a disposable backend under scheme and
+// unregisters it again once the test finishes, so tests can exercise
+// RegisterBackend/allAuthors/projectsIn without permanently mutating the
+// package-global registry every other test (including itself, re-run) sees.
+func registerTestBackend(t *testing.T, scheme string, b DistributorBackend) {
+	t.Helper()
+
+	RegisterBackend(scheme, b)
+	t.Cleanup(func() {
+		backendsMu.Lock()
+		defer backendsMu.Unlock()
+		delete(backends, scheme)
+	})
+}
+
+func TestRegisterBackend_Duplicate(t *testing.T) {
+	registerTestBackend(t, "test-dup", stubBackend{err: errors.New("boom")})
+
+	assert.Panics(t, func() {
+		RegisterBackend("test-dup", stubBackend{err: errors.New("boom")})
+	})
+}
+
+func TestBackends(t *testing.T) {
+	schemes := Backends()
+	assert.Equal(t, FileScheme, schemes[0], "the file:// backend should always be listed first")
+	assert.Contains(t, schemes, ModScheme)
+}
+
+func TestDistributorsIn(t *testing.T) {
+	defer makeTreeAndEnv(t)()
+
+	distros, err := DistributorsIn([]string{FileScheme})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, distros)
+
+	_, err = DistributorsIn([]string{"does-not-exist"})
+	assert.Error(t, err)
+}
+
+func TestAllAuthors_SkipsFailingNonFileBackend(t *testing.T) {
+	defer makeTreeAndEnv(t)()
+
+	registerTestBackend(t, "test-broken", stubBackend{err: errors.New("offline")})
+
+	authors, err := allAuthors([]string{FileScheme, "test-broken"})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, authors)
+}
+
+func TestAllAuthors_SkipsFailingFileBackend(t *testing.T) {
+	dir, removeAll := makeProjectTree(t)
+	defer removeAll()
+	require.NoError(t, os.RemoveAll(path.Join(dir, "src")))
+	defer patchEnv("GOPATH", dir)()
+
+	modDir, removeModDir := makeModCacheTree(t)
+	defer removeModDir()
+	defer patchEnv("GOMODCACHE", modDir)()
+
+	authors, err := allAuthors([]string{FileScheme, ModScheme})
+	assert.NoError(t, err, "a GOPATH with no src/ tree shouldn't stop the module cache from being reported")
+	assert.NotEmpty(t, authors)
+}
+
+func TestAllAuthors_ErrorsWhenEveryBackendFails(t *testing.T) {
+	dir, removeAll := makeProjectTree(t)
+	defer removeAll()
+	require.NoError(t, os.RemoveAll(path.Join(dir, "src")))
+	defer patchEnv("GOPATH", dir)()
+	defer patchEnv("GOMODCACHE", path.Join(dir, "does-not-exist"))()
+
+	_, err := allAuthors([]string{FileScheme, ModScheme})
+	assert.Error(t, err)
+}
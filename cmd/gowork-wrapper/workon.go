@@ -9,16 +9,84 @@ import (
 )
 
 func workon(c *cli.Context) {
-	distros, err := gowork.AllDistributors()
+	fmt.Printf("Backends: %v\n", strings.Join(gowork.Backends(), ", "))
+
+	var schemes []string
+	if scheme := c.String("backend"); scheme != "" {
+		schemes = []string{scheme}
+	}
+
+	distros, err := gowork.DistributorsIn(schemes)
 	if err != nil {
 		fmt.Println("Could not load distros.")
 		fmt.Println("Error:", err)
-	} else {
-		d := make([]string, len(distros))
-		for t, dist := range distros {
-			d[t] = string(dist)
+		return
+	}
+
+	d := make([]string, len(distros))
+	for t, dist := range distros {
+		d[t] = string(dist)
+	}
+
+	fmt.Printf("All available repos: %v\n", strings.Join(d, ", "))
+
+	needle := c.Args().First()
+	if needle == "" {
+		return
+	}
+
+	filters, err := projectFilters(c)
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
+
+	pCh := make(chan gowork.ProjectMatch)
+	eCh := make(chan error)
+	go gowork.FindProjectWithFiltersIn(needle, true, schemes, filters, pCh, eCh)
+
+	for pCh != nil || eCh != nil {
+		select {
+		case match, ok := <-pCh:
+			if !ok {
+				pCh = nil
+				continue
+			}
+
+			fmt.Printf("Match: %v\n", match.Project)
+
+		case err, ok := <-eCh:
+			if !ok {
+				eCh = nil
+				continue
+			}
+
+			fmt.Println("Error:", err)
+		}
+	}
+}
+
+// projectFilters builds the gowork.ProjectFilters requested via --dirty,
+// --branch and --remote.
+func projectFilters(c *cli.Context) ([]gowork.ProjectFilter, error) {
+	var filters []gowork.ProjectFilter
+
+	if c.Bool("dirty") {
+		filters = append(filters, gowork.DirtyFilter())
+	}
+
+	if branch := c.String("branch"); branch != "" {
+		filters = append(filters, gowork.BranchFilter(branch))
+	}
+
+	if remote := c.String("remote"); remote != "" {
+		name, substr, ok := strings.Cut(remote, "=")
+		if !ok {
+			return nil, fmt.Errorf("--remote must be in the form name=substr, got %q", remote)
 		}
 
-		fmt.Printf("All available repos: %v\n", strings.Join(d, ", "))
+		filters = append(filters, gowork.RemoteFilter(name, substr))
 	}
+
+	return filters, nil
 }
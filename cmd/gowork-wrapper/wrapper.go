@@ -61,7 +61,24 @@ func main() {
 			Name:   "workon",
 			Action: workon,
 			Usage:  "CD into project to work on it.",
-			Flags:  app.Flags,
+			Flags: append(app.Flags,
+				cli.StringFlag{
+					Name:  "backend, b",
+					Usage: "Restrict discovery to a single backend `SCHEME` (see Backends, e.g. \"file\" or \"mod\").",
+				},
+				cli.BoolFlag{
+					Name:  "dirty",
+					Usage: "Only match projects with uncommitted changes.",
+				},
+				cli.StringFlag{
+					Name:  "branch",
+					Usage: "Only match projects whose checked out branch is `BRANCH` (e.g. --branch=main).",
+				},
+				cli.StringFlag{
+					Name:  "remote",
+					Usage: "Only match projects with a remote `NAME=SUBSTR` whose URL contains substr (e.g. --remote=origin=github.com/...).",
+				},
+			),
 		},
 	}
 
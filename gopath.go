@@ -0,0 +1,56 @@
+package gowork
+
+import (
+	"go/build"
+	"os"
+	"path"
+	"strings"
+)
+
+// getGopathEntries returns every entry of GOPATH, splitting on
+// os.PathListSeparator the way the go command itself does, and falling
+// back to build.Default.GOPATH when GOPATH isn't set.
+func getGopathEntries() []string {
+	raw := os.Getenv("GOPATH")
+	if raw == "" {
+		raw = build.Default.GOPATH
+	}
+
+	var entries []string
+	for _, entry := range strings.Split(raw, string(os.PathListSeparator)) {
+		if entry != "" {
+			entries = append(entries, entry)
+		}
+	}
+
+	return entries
+}
+
+// firstExistingPath returns the first of candidates that exists on disk, or
+// candidates[0] if none of them do, so callers that only care about a
+// single path still get a sensible one to fail against.
+func firstExistingPath(candidates []string) string {
+	for _, candidate := range candidates {
+		if _, err := os.Stat(candidate); err == nil {
+			return candidate
+		}
+	}
+
+	if len(candidates) > 0 {
+		return candidates[0]
+	}
+
+	return ""
+}
+
+// gopathEntryFor returns the GOPATH entry p is checked out under using the
+// legacy src/ layout, or "" if none of the GOPATH entries has it.
+func gopathEntryFor(p Project) string {
+	for _, entry := range getGopathEntries() {
+		if _, err := os.Stat(path.Join(entry, "src", string(p))); err == nil {
+			return entry
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,56 @@
+package gowork
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetGopathEntries(t *testing.T) {
+	defer patchEnv("GOPATH", "/a"+string(os.PathListSeparator)+"/b")()
+	assert.Equal(t, []string{"/a", "/b"}, getGopathEntries())
+
+	defer patchEnv("GOPATH", "/a"+string(os.PathListSeparator)+string(os.PathListSeparator)+"/b")()
+	assert.Equal(t, []string{"/a", "/b"}, getGopathEntries())
+}
+
+func TestFirstExistingPath(t *testing.T) {
+	dir, removeAll := makeProjectTree(t)
+	defer removeAll()
+
+	existing := path.Join(dir, "src", "github.com")
+	assert.Equal(t, existing, firstExistingPath([]string{"/does-not-exist", existing}))
+	assert.Equal(t, "/does-not-exist", firstExistingPath([]string{"/does-not-exist"}))
+	assert.Equal(t, "", firstExistingPath(nil))
+}
+
+func TestMultiEntryGopath(t *testing.T) {
+	first, removeFirst := makeProjectTree(t)
+	defer removeFirst()
+
+	second, err := ioutil.TempDir("", "")
+	require.NoError(t, err)
+	defer os.RemoveAll(second)
+	require.NoError(t, os.MkdirAll(path.Join(second, "src", "bitbucket.org", "matt3o12", "extra"), 0777))
+
+	defer patchEnv("GOPATH", first+string(os.PathListSeparator)+second)()
+
+	distros, err := AllDistributors()
+	require.NoError(t, err)
+	expected := []Distributor{
+		"aaa", "bbb", "bitbucket.org", "ccc",
+		"code.google.com",
+		"github.com",
+	}
+	assert.Equal(t, expected, distros)
+
+	author := NewAuthor("bitbucket.org", "matt3o12")
+	projects, err := author.Projects()
+	require.NoError(t, err)
+	assert.Equal(t, []Project{"bitbucket.org/matt3o12/extra"}, projects)
+	assert.Equal(t, path.Join(second, "src", "bitbucket.org", "matt3o12"), author.AbsPath())
+}
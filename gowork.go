@@ -3,9 +3,9 @@ package gowork
 import (
 	"errors"
 	"fmt"
-	"io/ioutil"
 	"os"
 	"path"
+	"sort"
 	"strings"
 
 	"github.com/op/go-logging"
@@ -13,10 +13,6 @@ import (
 
 var log = logging.MustGetLogger("gowork")
 
-func getGopath() string {
-	return os.Getenv("GOPATH")
-}
-
 // Checks if dir is a proper directory (i.e. isDir returns true and it is
 // visible).
 func isProperDirectory(dir os.FileInfo) bool {
@@ -36,9 +32,17 @@ func isProperDirectory(dir os.FileInfo) bool {
 // A Distributor is github, bitbucket or every other hoster for goprojects.
 type Distributor string
 
-// AbsPath returns the absolute path for this distributor.
+// AbsPath returns the absolute path for this distributor: the first GOPATH
+// entry that already has it checked out, or the first GOPATH entry if none
+// do.
 func (d Distributor) AbsPath() string {
-	return path.Join(getGopath(), "src", d.Name())
+	entries := getGopathEntries()
+	candidates := make([]string, len(entries))
+	for i, entry := range entries {
+		candidates[i] = path.Join(entry, "src", d.Name())
+	}
+
+	return firstExistingPath(candidates)
 }
 
 // Name returns the name of the distro (e.g. github.com)
@@ -46,21 +50,43 @@ func (d Distributor) Name() string {
 	return string(d)
 }
 
-// AllDistributors returns all `Distributor`s in the gopath.
+// AllDistributors returns all `Distributor`s known to any registered
+// DistributorBackend (see RegisterBackend).
 func AllDistributors() ([]Distributor, error) {
-	dirs, err := ioutil.ReadDir(path.Join(getGopath(), "src"))
+	return DistributorsIn(nil)
+}
+
+// DistributorsIn is like AllDistributors but restricts discovery to the
+// given backend schemes (see Backends). A nil or empty schemes searches
+// every registered backend.
+func DistributorsIn(schemes []string) ([]Distributor, error) {
+	authors, err := allAuthors(schemes)
 	if err != nil {
 		return nil, err
 	}
 
-	var distrbutors []Distributor
-	for _, theDir := range dirs {
-		if isProperDirectory(theDir) {
-			distrbutors = append(distrbutors, Distributor(theDir.Name()))
+	seen := make(map[Distributor]bool)
+	var distros []Distributor
+	for _, a := range authors {
+		d := a.Distributor()
+		if !seen[d] {
+			seen[d] = true
+			distros = append(distros, d)
 		}
 	}
 
-	return distrbutors, nil
+	sort.Slice(distros, func(i, j int) bool {
+		if distros[i] == LocalDistributor {
+			return distros[j] != LocalDistributor
+		}
+		if distros[j] == LocalDistributor {
+			return false
+		}
+
+		return distros[i] < distros[j]
+	})
+
+	return distros, nil
 }
 
 // An Author is someone who hosts code on a repo. The format is:
@@ -83,10 +109,31 @@ func (a Author) Split() (distro Distributor, name string) {
 	return
 }
 
-// AbsPath returns the absolute path to all the author's projects.
+// AbsPath returns the absolute path to all the author's projects: the first
+// GOPATH entry that already has it checked out, or the first GOPATH entry
+// if none do.
 func (a Author) AbsPath() string {
 	distro, name := a.Split()
-	return path.Join(distro.AbsPath(), name)
+
+	entries := getGopathEntries()
+	candidates := make([]string, len(entries))
+	for i, entry := range entries {
+		candidates[i] = path.Join(entry, "src", distro.Name(), name)
+	}
+
+	return firstExistingPath(candidates)
+}
+
+// Distributor returns the distro the author hosts its code on.
+func (a Author) Distributor() Distributor {
+	distro, _ := a.Split()
+	return distro
+}
+
+// Name returns the author's name without its distro.
+func (a Author) Name() string {
+	_, name := a.Split()
+	return name
 }
 
 // Error returned when author could not be found.
@@ -115,16 +162,19 @@ func FindAuthor(name string) (Author, error) {
 	return "", ErrAuthorCouldNotBeFound
 }
 
-// FindAuthorIn tries to find author in the given distribution.
+// FindAuthorIn tries to find author in the given distribution, asking every
+// registered DistributorBackend (see Distributor.Authors) rather than just
+// the legacy GOPATH/src layout, so an author only cached under
+// GOPATH/pkg/mod or surfaced by a go.work workspace is still found.
 func FindAuthorIn(name string, distro Distributor) (Author, error) {
-	files, err := ioutil.ReadDir(distro.AbsPath())
+	authors, err := distro.Authors()
 	if err != nil {
 		return "", err
 	}
 
-	for _, dir := range files {
-		if isProperDirectory(dir) && strings.EqualFold(name, dir.Name()) {
-			return NewAuthor(distro, dir.Name()), nil
+	for _, a := range authors {
+		if _, authorName := a.Split(); strings.EqualFold(name, authorName) {
+			return a, nil
 		}
 	}
 
@@ -172,3 +222,252 @@ func (p Project) Name() string {
 func (p Project) AbsPath() string {
 	return path.Join(p.Author().AbsPath(), p.Name())
 }
+
+// Version returns the module version this project is cached at in the
+// module cache (e.g. "v1.2.0"). Projects that only exist under the legacy
+// GOPATH/src layout have no version, so Version returns "" for them.
+func (p Project) Version() string {
+	version, _, _ := modCacheLookup(p)
+	return version
+}
+
+// AbsPaths returns every absolute path this project was found at, asking
+// every registered DistributorBackend (see RegisterBackend) and keeping
+// only the paths that actually exist. A project that is checked out under
+// GOPATH/src and also cached under GOPATH/pkg/mod is reported once with
+// both paths, in backend order (file:// first).
+func (p Project) AbsPaths() []string {
+	var paths []string
+	for _, scheme := range Backends() {
+		b, ok := backend(scheme)
+		if !ok {
+			continue
+		}
+
+		abs := b.AbsPath(p)
+		if abs == "" {
+			continue
+		}
+
+		if _, err := os.Stat(abs); err == nil {
+			paths = append(paths, abs)
+		}
+	}
+
+	return paths
+}
+
+// rootPath returns the first absolute path a registered backend resolves p
+// to (see AbsPaths), falling back to the legacy, backend-unaware AbsPath if
+// no backend has ever heard of p, so callers still get a path to fail
+// against.
+func (p Project) rootPath() string {
+	if paths := p.AbsPaths(); len(paths) > 0 {
+		return paths[0]
+	}
+
+	return p.AbsPath()
+}
+
+// Authors returns all `Author`s found for this distributor across every
+// registered DistributorBackend.
+func (d Distributor) Authors() ([]Author, error) {
+	return authorsIn(nil, d)
+}
+
+// authorsIn is like Distributor.Authors, but restricts discovery to the
+// given backend schemes (see Backends). A nil or empty schemes searches
+// every registered backend.
+func authorsIn(schemes []string, d Distributor) ([]Author, error) {
+	authors, err := allAuthors(schemes)
+	if err != nil {
+		return nil, err
+	}
+
+	var filtered []Author
+	for _, a := range authors {
+		if a.Distributor() == d {
+			filtered = append(filtered, a)
+		}
+	}
+
+	return filtered, nil
+}
+
+// Projects returns all `Project`s the author has across every registered
+// DistributorBackend, so a module only present under GOPATH/pkg/mod still
+// shows up.
+func (a Author) Projects() ([]Project, error) {
+	return projectsIn(nil, a)
+}
+
+// MatchType describes how a search term matched a project in FindProject.
+type MatchType int
+
+// The kinds of matches FindProject can report. A project match always beats
+// an author match, which in turn always beats a distro match.
+const (
+	MatchDistro MatchType = iota
+	MatchAuthor
+	MatchProject
+)
+
+// getBestMatch returns the strongest of the three matches.
+func getBestMatch(distro, author, project bool) MatchType {
+	switch {
+	case project:
+		return MatchProject
+	case author:
+		return MatchAuthor
+	default:
+		return MatchDistro
+	}
+}
+
+// matches reports whether needle matched name. If search is true, needle only
+// has to be a case-insensitive substring of name; otherwise name has to equal
+// needle exactly (also case-insensitively).
+func matches(name, needle string, search bool) bool {
+	if search {
+		return strings.Contains(strings.ToLower(name), strings.ToLower(needle))
+	}
+
+	return strings.EqualFold(name, needle)
+}
+
+// ProjectMatch is a single result returned by FindProject.
+type ProjectMatch struct {
+	Project Project
+	Match   MatchType
+
+	// GopathEntry is the GOPATH entry Project was found under using the
+	// legacy src/ layout, or "" if it wasn't found there (e.g. it only
+	// exists in the module cache or a workspace).
+	GopathEntry string
+}
+
+// ProjectFilter further restricts which projects FindProjectWithFilters
+// reports. See DirtyFilter, BranchFilter and RemoteFilter.
+type ProjectFilter func(Project) bool
+
+// DirtyFilter only keeps projects whose git worktree has uncommitted
+// changes.
+func DirtyFilter() ProjectFilter {
+	return func(p Project) bool {
+		repo, err := p.Repo()
+		if err != nil {
+			return false
+		}
+
+		return repo.IsDirty()
+	}
+}
+
+// BranchFilter only keeps projects whose currently checked out branch is
+// branch.
+func BranchFilter(branch string) ProjectFilter {
+	return func(p Project) bool {
+		repo, err := p.Repo()
+		if err != nil {
+			return false
+		}
+
+		current, _ := repo.Head()
+		return current == branch
+	}
+}
+
+// RemoteFilter only keeps projects that have a remote called name whose URL
+// contains substr (e.g. RemoteFilter("origin", "github.com/matt3o12")).
+func RemoteFilter(name, substr string) ProjectFilter {
+	return func(p Project) bool {
+		repo, err := p.Repo()
+		if err != nil {
+			return false
+		}
+
+		url, ok := repo.Remotes()[name]
+		return ok && strings.Contains(url, substr)
+	}
+}
+
+func passesFilters(p Project, filters []ProjectFilter) bool {
+	for _, filter := range filters {
+		if !filter(p) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// FindProject walks every distro/author/project combination known to any
+// registered DistributorBackend looking for needle, sending every match to
+// pCh. If search is
+// true, needle only has to be a substring of the distro, author or project
+// name; otherwise it has to match one of them exactly. pCh and eCh are both
+// closed once the search is done (or aborted because of an error), so
+// FindProject is meant to be run in its own goroutine.
+func FindProject(needle string, search bool, pCh chan ProjectMatch, eCh chan error) {
+	FindProjectWithFilters(needle, search, nil, pCh, eCh)
+}
+
+// FindProjectWithFilters is like FindProject, but only reports projects
+// that also satisfy every filter (see ProjectFilter, DirtyFilter,
+// BranchFilter, RemoteFilter), e.g. to only find dirty projects on a given
+// branch.
+func FindProjectWithFilters(needle string, search bool, filters []ProjectFilter, pCh chan ProjectMatch, eCh chan error) {
+	FindProjectWithFiltersIn(needle, search, nil, filters, pCh, eCh)
+}
+
+// FindProjectWithFiltersIn is like FindProjectWithFilters, but restricts
+// discovery to the given backend schemes (see Backends), the same way
+// DistributorsIn restricts AllDistributors. A nil or empty schemes searches
+// every registered backend.
+func FindProjectWithFiltersIn(needle string, search bool, schemes []string, filters []ProjectFilter, pCh chan ProjectMatch, eCh chan error) {
+	defer close(pCh)
+	defer close(eCh)
+
+	distros, err := DistributorsIn(schemes)
+	if err != nil {
+		eCh <- err
+		return
+	}
+
+	for _, distro := range distros {
+		distroMatch := matches(distro.Name(), needle, search)
+
+		authors, err := authorsIn(schemes, distro)
+		if err != nil {
+			eCh <- err
+			return
+		}
+
+		for _, author := range authors {
+			authorMatch := matches(author.Name(), needle, search)
+
+			projects, err := projectsIn(schemes, author)
+			if err != nil {
+				eCh <- err
+				return
+			}
+
+			for _, project := range projects {
+				projectMatch := matches(project.Name(), needle, search)
+				if !distroMatch && !authorMatch && !projectMatch {
+					continue
+				}
+
+				if !passesFilters(project, filters) {
+					continue
+				}
+
+				pCh <- ProjectMatch{
+					Project:     project,
+					Match:       getBestMatch(distroMatch, authorMatch, projectMatch),
+					GopathEntry: gopathEntryFor(project),
+				}
+			}
+		}
+	}
+}
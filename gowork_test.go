@@ -147,8 +147,8 @@ func TestDistributor_Authors(t *testing.T) {
 func TestAllDistributors(t *testing.T) {
 	defer patchEnv("GOPATH", "/foo/bar")()
 	distros, err := AllDistributors()
-	assert.True(t, os.IsNotExist(err), "Expected gopath not to exist")
-	assert.Nil(t, distros, "No distros exepcted.")
+	assert.NoError(t, err, "a nonexistent GOPATH/src shouldn't fail discovery on its own")
+	assert.Empty(t, distros, "No distros exepcted.")
 
 	defer makeTreeAndEnv(t)()
 
@@ -182,8 +182,7 @@ func TestAuthor_Projects(t *testing.T) {
 
 	defer patchEnv("GOPATH", "not-exist")()
 	projects, err := author.Projects()
-	msg := "open not-exist/src/github.com/matt3o12: no such file or directory"
-	assert.EqualError(t, err, msg)
+	assert.NoError(t, err, "a nonexistent GOPATH/src shouldn't fail discovery on its own")
 	assert.Empty(t, projects, "Expected to return no projects, got: %v", projects)
 
 	defer makeTreeAndEnv(t)()
@@ -200,7 +199,7 @@ func TestFindAuthor(t *testing.T) {
 
 	msg := "Expected no author to be found, got: %v"
 	assert.Equal(t, Author(""), author, msg, author)
-	assert.EqualError(t, err, "open not-exist/src: no such file or directory")
+	assert.Equal(t, ErrAuthorCouldNotBeFound, err, "a nonexistent GOPATH/src shouldn't fail discovery on its own")
 
 	defer makeTreeAndEnv(t)()
 	author, err = FindAuthor("matt3o12")
@@ -238,6 +237,30 @@ func TestFindAuthorIn(t *testing.T) {
 	assertNotAuthor("not-exist", "user")
 }
 
+func TestFindAuthorIn_ModCacheOnly(t *testing.T) {
+	defer makeTreeAndEnv(t)()
+
+	modDir, removeModDir := makeModCacheTree(t)
+	defer removeModDir()
+	defer patchEnv("GOMODCACHE", modDir)()
+
+	author, err := FindAuthorIn("BurntSushi", "github.com")
+	assert.NoError(t, err, "an author only cached under GOPATH/pkg/mod should still be found")
+	assert.Equal(t, NewAuthor("github.com", "BurntSushi"), author)
+}
+
+func TestFindAuthor_ModCacheOnly(t *testing.T) {
+	defer patchEnv("GOPATH", "not-exist")()
+
+	modDir, removeModDir := makeModCacheTree(t)
+	defer removeModDir()
+	defer patchEnv("GOMODCACHE", modDir)()
+
+	author, err := FindAuthor("BurntSushi")
+	assert.NoError(t, err, "an author only cached under GOPATH/pkg/mod should still be found")
+	assert.Equal(t, NewAuthor("github.com", "BurntSushi"), author)
+}
+
 func TestIsProperDirectory(t *testing.T) {
 	dir, err := ioutil.TempDir("", "")
 	require.NoError(t, err)
@@ -357,6 +380,7 @@ func TestProjectChToSlice(t *testing.T) {
 
 func TestFindProject(t *testing.T) {
 	defer makeTreeAndEnv(t)()
+	gopath := os.Getenv("GOPATH")
 
 	assertProject := func(expectedProj []ProjectMatch, projs []ProjectMatch, err error, msg ...interface{}) {
 		assert.Equal(t, expectedProj, projs, msg...)
@@ -372,13 +396,13 @@ func TestFindProject(t *testing.T) {
 	// test find all...
 	projs, err := findProject("", true)
 	expectedProjs := []ProjectMatch{
-		{"aaa/user/project", MatchProject},
-		{"bbb/user/project", MatchProject},
-		{"ccc/user/project", MatchProject},
-		{"code.google.com/p/cascadia", MatchProject},
-		{"github.com/matt3o12/gowork", MatchProject},
-		{"github.com/matt3o12/termui-widgets", MatchProject},
-		{"github.com/stretchr/testify", MatchProject},
+		{Project: "aaa/user/project", Match: MatchProject, GopathEntry: gopath},
+		{Project: "bbb/user/project", Match: MatchProject, GopathEntry: gopath},
+		{Project: "ccc/user/project", Match: MatchProject, GopathEntry: gopath},
+		{Project: "code.google.com/p/cascadia", Match: MatchProject, GopathEntry: gopath},
+		{Project: "github.com/matt3o12/gowork", Match: MatchProject, GopathEntry: gopath},
+		{Project: "github.com/matt3o12/termui-widgets", Match: MatchProject, GopathEntry: gopath},
+		{Project: "github.com/stretchr/testify", Match: MatchProject, GopathEntry: gopath},
 	}
 	assertProject(expectedProjs, projs, err)
 
@@ -389,9 +413,9 @@ func TestFindProject(t *testing.T) {
 	// Test find proj*
 	projs, err = findProject("proj", true)
 	expectedProjs = []ProjectMatch{
-		{"aaa/user/project", MatchProject},
-		{"bbb/user/project", MatchProject},
-		{"ccc/user/project", MatchProject},
+		{Project: "aaa/user/project", Match: MatchProject, GopathEntry: gopath},
+		{Project: "bbb/user/project", Match: MatchProject, GopathEntry: gopath},
+		{Project: "ccc/user/project", Match: MatchProject, GopathEntry: gopath},
 	}
 
 	assertProject(expectedProjs, projs, err)
@@ -399,9 +423,9 @@ func TestFindProject(t *testing.T) {
 	// Test search distro
 	projs, err = findProject("github.com", true)
 	expectedProjs = []ProjectMatch{
-		{"github.com/matt3o12/gowork", MatchDistro},
-		{"github.com/matt3o12/termui-widgets", MatchDistro},
-		{"github.com/stretchr/testify", MatchDistro},
+		{Project: "github.com/matt3o12/gowork", Match: MatchDistro, GopathEntry: gopath},
+		{Project: "github.com/matt3o12/termui-widgets", Match: MatchDistro, GopathEntry: gopath},
+		{Project: "github.com/stretchr/testify", Match: MatchDistro, GopathEntry: gopath},
 	}
 
 	assertProject(expectedProjs, projs, err)
@@ -409,23 +433,47 @@ func TestFindProject(t *testing.T) {
 	// TEst search author
 	projs, err = findProject("matt3o12", false)
 	expectedProjs = []ProjectMatch{
-		{"github.com/matt3o12/gowork", MatchAuthor},
-		{"github.com/matt3o12/termui-widgets", MatchAuthor},
+		{Project: "github.com/matt3o12/gowork", Match: MatchAuthor, GopathEntry: gopath},
+		{Project: "github.com/matt3o12/termui-widgets", Match: MatchAuthor, GopathEntry: gopath},
 	}
 
 	assertProject(expectedProjs, projs, err)
 
 	// Test what happens when search term matches the, distro, author and proj.
-	githubFolder := path.Join(os.Getenv("GOPATH"), "src", "github.com")
+	githubFolder := path.Join(gopath, "src", "github.com")
 	newFolder := path.Join(githubFolder, "gitter", "git")
 	os.RemoveAll(githubFolder)
 	os.MkdirAll(newFolder, 0777)
 
 	projs, err = findProject("git", true)
-	expectedProjs = []ProjectMatch{{"github.com/gitter/git", MatchProject}}
+	expectedProjs = []ProjectMatch{
+		{Project: "github.com/gitter/git", Match: MatchProject, GopathEntry: gopath},
+	}
 	assertProject(expectedProjs, projs, err)
 }
 
+func TestFindProjectWithFiltersIn_RestrictsToScheme(t *testing.T) {
+	defer makeTreeAndEnv(t)()
+
+	modDir, removeModDir := makeModCacheTree(t)
+	defer removeModDir()
+	defer patchEnv("GOMODCACHE", modDir)()
+
+	findProject := func(schemes []string) ([]ProjectMatch, error) {
+		pCh, eCh := makeChs()
+		go FindProjectWithFiltersIn("BurntSushi", false, schemes, nil, pCh, eCh)
+		return ProjectChToSlice(pCh, eCh)
+	}
+
+	projs, err := findProject([]string{FileScheme})
+	assert.NoError(t, err)
+	assert.Empty(t, projs, "an author only cached under GOPATH/pkg/mod shouldn't match -b file")
+
+	projs, err = findProject([]string{ModScheme})
+	assert.NoError(t, err)
+	assert.Equal(t, []ProjectMatch{{Project: "github.com/BurntSushi/toml", Match: MatchAuthor}}, projs)
+}
+
 func TestGetBestMatch(t *testing.T) {
 	msg := "Distro: %#v, Author: %#v, Project: %#v\n\n"
 	t.Log("Values for matches:")
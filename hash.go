@@ -0,0 +1,174 @@
+package gowork
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/go-git/go-billy/v5/osfs"
+	"github.com/go-git/go-git/v5/plumbing/format/gitignore"
+	"golang.org/x/mod/sumdb/dirhash"
+)
+
+// FileHash is the sha256 of a single file within a Project, relative to the
+// project's root.
+type FileHash struct {
+	Path   string
+	Sha256 string
+}
+
+// HashOption customizes how Manifest and Hash walk a Project's files. See
+// HonorGitignore.
+type HashOption func(*hashOptions)
+
+type hashOptions struct {
+	honorGitignore bool
+}
+
+// HonorGitignore makes Manifest and Hash skip files excluded by the
+// project's .gitignore files and .git/info/exclude, the same way `git
+// status` would. Without it, every regular file under the project root is
+// included (besides .git/ itself), which is what makes the default Hash
+// match what `go mod download` would have produced for the same tree.
+func HonorGitignore() HashOption {
+	return func(o *hashOptions) {
+		o.honorGitignore = true
+	}
+}
+
+// manifestFiles returns every regular file under p's root, relative to it
+// and slash-separated, sorted. It skips .git/ so the result lines up with
+// what `go mod download` would see, and, when HonorGitignore is given, also
+// skips everything p's own gitignore files would exclude.
+func (p Project) manifestFiles(opts ...HashOption) ([]string, error) {
+	root := p.rootPath()
+
+	var o hashOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	var matcher gitignore.Matcher
+	if o.honorGitignore {
+		patterns, err := gitignore.ReadPatterns(osfs.New(root), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		matcher = gitignore.NewMatcher(patterns)
+	}
+
+	var files []string
+	err := filepath.Walk(root, func(name string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(root, name)
+		if err != nil {
+			return err
+		}
+
+		if rel == "." {
+			return nil
+		}
+
+		if info.IsDir() {
+			if info.Name() == ".git" {
+				return filepath.SkipDir
+			}
+
+			if matcher != nil && matcher.Match(strings.Split(filepath.ToSlash(rel), "/"), true) {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		if matcher != nil && matcher.Match(strings.Split(filepath.ToSlash(rel), "/"), false) {
+			return nil
+		}
+
+		files = append(files, filepath.ToSlash(rel))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(files)
+	return files, nil
+}
+
+// Manifest returns the sha256 of every regular file under p's root, sorted
+// by path. This is the intermediate result Hash condenses into a single
+// "h1:" digest. Pass HonorGitignore to exclude files p's .gitignore would.
+func (p Project) Manifest(opts ...HashOption) ([]FileHash, error) {
+	root := p.rootPath()
+
+	files, err := p.manifestFiles(opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := make([]FileHash, len(files))
+	for i, rel := range files {
+		sum, err := hashFile(filepath.Join(root, filepath.FromSlash(rel)))
+		if err != nil {
+			return nil, err
+		}
+
+		manifest[i] = FileHash{Path: rel, Sha256: sum}
+	}
+
+	return manifest, nil
+}
+
+func hashFile(name string) (string, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// Hash computes p's content hash the same way `go mod download` would: the
+// Go module directory hash ("h1:" prefix), using the same algorithm as
+// golang.org/x/mod/sumdb/dirhash.HashDir. Every regular file under p's root
+// is hashed and rendered as "<hex>  <path>\n" in sorted order, and the
+// SHA-256 of that concatenation is base64-encoded. .git/ is skipped so the
+// default result matches what `go mod download` would have produced for
+// the same tree; pass HonorGitignore to also exclude gitignored files.
+func (p Project) Hash(opts ...HashOption) (string, error) {
+	root := p.rootPath()
+	prefix := fmt.Sprintf("%v@%v", string(p), p.Version())
+
+	files, err := p.manifestFiles(opts...)
+	if err != nil {
+		return "", err
+	}
+
+	prefixed := make([]string, len(files))
+	for i, f := range files {
+		prefixed[i] = prefix + "/" + f
+	}
+
+	open := func(name string) (io.ReadCloser, error) {
+		rel := strings.TrimPrefix(name, prefix+"/")
+		return os.Open(filepath.Join(root, filepath.FromSlash(rel)))
+	}
+
+	return dirhash.Hash1(prefixed, open)
+}
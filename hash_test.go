@@ -0,0 +1,79 @@
+package gowork
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectManifestAndHash(t *testing.T) {
+	defer makeTreeAndEnv(t)()
+
+	projectDir := path.Join(os.Getenv("GOPATH"), "src", "github.com", "matt3o12", "gowork")
+	require.NoError(t, ioutil.WriteFile(path.Join(projectDir, "main.go"), []byte("package main\n"), 0644))
+
+	project := Project("github.com/matt3o12/gowork")
+	manifest, err := project.Manifest()
+	require.NoError(t, err)
+	require.Len(t, manifest, 1)
+	assert.Equal(t, "main.go", manifest[0].Path)
+	assert.Len(t, manifest[0].Sha256, 64)
+
+	hash, err := project.Hash()
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(hash, "h1:"))
+
+	hash2, err := project.Hash()
+	require.NoError(t, err)
+	assert.Equal(t, hash, hash2, "hashing the same tree twice should be deterministic")
+
+	require.NoError(t, ioutil.WriteFile(path.Join(projectDir, "other.go"), []byte("package main\n"), 0644))
+	hash3, err := project.Hash()
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, hash3, "adding a file should change the hash")
+}
+
+func TestProjectManifest_HonorGitignore(t *testing.T) {
+	defer makeTreeAndEnv(t)()
+
+	projectDir := path.Join(os.Getenv("GOPATH"), "src", "github.com", "matt3o12", "gowork")
+	require.NoError(t, ioutil.WriteFile(path.Join(projectDir, "main.go"), []byte("package main\n"), 0644))
+	require.NoError(t, ioutil.WriteFile(path.Join(projectDir, "build.log"), []byte("build output\n"), 0644))
+	require.NoError(t, ioutil.WriteFile(path.Join(projectDir, ".gitignore"), []byte("*.log\n"), 0644))
+
+	project := Project("github.com/matt3o12/gowork")
+
+	manifest, err := project.Manifest()
+	require.NoError(t, err)
+	assert.Len(t, manifest, 3, "without HonorGitignore, build.log and .gitignore are included too")
+
+	manifest, err = project.Manifest(HonorGitignore())
+	require.NoError(t, err)
+	require.Len(t, manifest, 2, "HonorGitignore should exclude build.log")
+	assert.Equal(t, ".gitignore", manifest[0].Path)
+	assert.Equal(t, "main.go", manifest[1].Path)
+
+	hash, err := project.Hash()
+	require.NoError(t, err)
+	ignoredHash, err := project.Hash(HonorGitignore())
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, ignoredHash, "HonorGitignore should change the hash by excluding build.log")
+}
+
+func TestProjectManifest_SkipsGitDir(t *testing.T) {
+	defer makeTreeAndEnv(t)()
+
+	projectDir := path.Join(os.Getenv("GOPATH"), "src", "github.com", "matt3o12", "gowork")
+	gitDir := path.Join(projectDir, ".git")
+	require.NoError(t, os.MkdirAll(gitDir, 0777))
+	require.NoError(t, ioutil.WriteFile(path.Join(gitDir, "HEAD"), []byte("ref: refs/heads/master\n"), 0644))
+
+	manifest, err := Project("github.com/matt3o12/gowork").Manifest()
+	require.NoError(t, err)
+	assert.Empty(t, manifest)
+}
@@ -0,0 +1,192 @@
+package gowork
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+)
+
+// getModCache returns the directory the go tool caches downloaded modules
+// in, honoring GOMODCACHE when it is set and falling back to the
+// conventional $GOPATH/pkg/mod otherwise.
+func getModCache() string {
+	if dir := os.Getenv("GOMODCACHE"); dir != "" {
+		return dir
+	}
+
+	entries := getGopathEntries()
+	if len(entries) == 0 {
+		return path.Join("", "pkg", "mod")
+	}
+
+	return path.Join(entries[0], "pkg", "mod")
+}
+
+// escapeModPath encodes name the way the module cache does on disk: every
+// uppercase letter is replaced by '!' followed by its lowercase form, so the
+// cache stays usable on case-insensitive filesystems.
+func escapeModPath(name string) string {
+	var b strings.Builder
+	for _, r := range name {
+		if r >= 'A' && r <= 'Z' {
+			b.WriteByte('!')
+			b.WriteRune(r + ('a' - 'A'))
+			continue
+		}
+
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
+
+// unescapeModPath reverses escapeModPath, turning a module cache directory
+// name such as "!burnt!sushi" back into "BurntSushi".
+func unescapeModPath(escaped string) (string, error) {
+	var b strings.Builder
+	for i := 0; i < len(escaped); i++ {
+		c := escaped[i]
+		if c != '!' {
+			b.WriteByte(c)
+			continue
+		}
+
+		i++
+		if i >= len(escaped) {
+			return "", fmt.Errorf("gowork: malformed module cache path %q", escaped)
+		}
+
+		b.WriteByte(escaped[i] - 'a' + 'A')
+	}
+
+	return b.String(), nil
+}
+
+// splitModVersion splits "toml@v1.2.0" into "toml" and "v1.2.0". If name has
+// no "@version" suffix, version is returned empty.
+func splitModVersion(name string) (project, version string) {
+	if i := strings.LastIndex(name, "@"); i >= 0 {
+		return name[:i], name[i+1:]
+	}
+
+	return name, ""
+}
+
+// modCacheMetaDirs are top-level entries under the module cache that hold
+// its own bookkeeping rather than a cached module, so they're never real
+// Distributors.
+var modCacheMetaDirs = map[string]bool{
+	"cache": true,
+	"sumdb": true,
+}
+
+// modCacheDistributors returns every Distributor that has at least one
+// author cached in the module cache.
+func modCacheDistributors() ([]Distributor, error) {
+	dirs, err := ioutil.ReadDir(getModCache())
+	if err != nil {
+		return nil, err
+	}
+
+	var distros []Distributor
+	for _, theDir := range dirs {
+		if !isProperDirectory(theDir) || modCacheMetaDirs[theDir.Name()] {
+			continue
+		}
+
+		name, err := unescapeModPath(theDir.Name())
+		if err != nil {
+			log.Debug("Skipping unreadable module cache distro %v: %v", theDir.Name(), err)
+			continue
+		}
+
+		distros = append(distros, Distributor(name))
+	}
+
+	return distros, nil
+}
+
+// modCacheAuthors returns every Author distro has cached in the module
+// cache.
+func modCacheAuthors(distro Distributor) ([]Author, error) {
+	dir := path.Join(getModCache(), escapeModPath(distro.Name()))
+	dirs, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var authors []Author
+	for _, theDir := range dirs {
+		if !isProperDirectory(theDir) {
+			continue
+		}
+
+		name, err := unescapeModPath(theDir.Name())
+		if err != nil {
+			log.Debug("Skipping unreadable module cache author %v: %v", theDir.Name(), err)
+			continue
+		}
+
+		authors = append(authors, NewAuthor(distro, name))
+	}
+
+	return authors, nil
+}
+
+// modCacheProjects returns every Project cached for author in the module
+// cache, regardless of which version they were cached at.
+func modCacheProjects(author Author) ([]Project, error) {
+	distro, name := author.Split()
+	dir := path.Join(getModCache(), escapeModPath(distro.Name()), escapeModPath(name))
+	dirs, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var projects []Project
+	for _, theDir := range dirs {
+		if !isProperDirectory(theDir) {
+			continue
+		}
+
+		rawName, _ := splitModVersion(theDir.Name())
+		projectName, err := unescapeModPath(rawName)
+		if err != nil {
+			log.Debug("Skipping unreadable module cache project %v: %v", theDir.Name(), err)
+			continue
+		}
+
+		projects = append(projects, NewProject(author, projectName))
+	}
+
+	return projects, nil
+}
+
+// modCacheLookup finds p in the module cache, returning the version it was
+// cached at and its absolute path. ok is false if p isn't cached there.
+func modCacheLookup(p Project) (version, absPath string, ok bool) {
+	distro, author, name := p.Split()
+	dir := path.Join(getModCache(), escapeModPath(distro.Name()), escapeModPath(author.Name()))
+	dirs, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return "", "", false
+	}
+
+	for _, theDir := range dirs {
+		if !isProperDirectory(theDir) {
+			continue
+		}
+
+		rawName, ver := splitModVersion(theDir.Name())
+		decoded, err := unescapeModPath(rawName)
+		if err != nil || decoded != name {
+			continue
+		}
+
+		return ver, path.Join(dir, theDir.Name()), true
+	}
+
+	return "", "", false
+}
@@ -0,0 +1,100 @@
+package gowork
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func makeModCacheTree(t *testing.T) (string, deferFunction) {
+	dir, err := ioutil.TempDir("", "")
+	require.NoError(t, err)
+
+	modules := []string{
+		path.Join("github.com", "!burnt!sushi", "toml@v1.2.0"),
+		path.Join("github.com", "matt3o12", "gowork@v0.1.0"),
+	}
+
+	for _, module := range modules {
+		require.NoError(t, os.MkdirAll(path.Join(dir, module), 0777))
+	}
+
+	return dir, func() {
+		os.RemoveAll(dir)
+	}
+}
+
+func TestEscapeModPath(t *testing.T) {
+	assert.Equal(t, "!burnt!sushi", escapeModPath("BurntSushi"))
+	assert.Equal(t, "github.com", escapeModPath("github.com"))
+
+	decoded, err := unescapeModPath("!burnt!sushi")
+	assert.NoError(t, err)
+	assert.Equal(t, "BurntSushi", decoded)
+
+	_, err = unescapeModPath("foo!")
+	assert.Error(t, err)
+}
+
+func TestSplitModVersion(t *testing.T) {
+	project, version := splitModVersion("toml@v1.2.0")
+	assert.Equal(t, "toml", project)
+	assert.Equal(t, "v1.2.0", version)
+
+	project, version = splitModVersion("toml")
+	assert.Equal(t, "toml", project)
+	assert.Equal(t, "", version)
+}
+
+func TestAllDistributors_ModCache(t *testing.T) {
+	defer makeTreeAndEnv(t)()
+
+	modDir, removeModDir := makeModCacheTree(t)
+	defer removeModDir()
+	defer patchEnv("GOMODCACHE", modDir)()
+
+	distros, err := AllDistributors()
+	require.NoError(t, err)
+	expected := []Distributor{
+		"aaa", "bbb", "ccc",
+		"code.google.com",
+		"github.com",
+	}
+	assert.Equal(t, expected, distros)
+}
+
+func TestAllDistributors_ModCache_SkipsCacheDir(t *testing.T) {
+	defer makeTreeAndEnv(t)()
+
+	modDir, removeModDir := makeModCacheTree(t)
+	defer removeModDir()
+	require.NoError(t, os.MkdirAll(path.Join(modDir, "cache", "download"), 0777))
+	require.NoError(t, os.MkdirAll(path.Join(modDir, "sumdb"), 0777))
+	defer patchEnv("GOMODCACHE", modDir)()
+
+	distros, err := AllDistributors()
+	require.NoError(t, err)
+	assert.NotContains(t, distros, Distributor("cache"))
+	assert.NotContains(t, distros, Distributor("sumdb"))
+}
+
+func TestProject_Version(t *testing.T) {
+	defer makeTreeAndEnv(t)()
+
+	modDir, removeModDir := makeModCacheTree(t)
+	defer removeModDir()
+	defer patchEnv("GOMODCACHE", modDir)()
+
+	toml := Project("github.com/BurntSushi/toml")
+	assert.Equal(t, "v1.2.0", toml.Version())
+
+	gowork := Project("github.com/matt3o12/gowork")
+	assert.Equal(t, "v0.1.0", gowork.Version())
+	assert.Len(t, gowork.AbsPaths(), 2)
+
+	assert.Equal(t, "", Project("github.com/matt3o12/termui-widgets").Version())
+}
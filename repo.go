@@ -0,0 +1,155 @@
+package gowork
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/go-git/go-git/v5"
+)
+
+// ProjectRepo exposes the git state of a Project, see Project.Repo.
+type ProjectRepo struct {
+	repo *git.Repository
+}
+
+// Repo opens the git repository p belongs to, rooted at the first path a
+// registered DistributorBackend resolves p to (see Project.AbsPaths). If
+// that path is itself a bare repository (no working tree, just HEAD,
+// objects/ and refs/ at its root), it is opened directly. Otherwise Repo
+// walks up looking for a `.git` entry, resolving the gitdir file left
+// behind in worktrees, the same way `git` itself does, but never past
+// gitSearchBoundary: unlike the legacy GOPATH/src layout, where a project
+// can be a subdirectory of a repository checked out higher up (see
+// gitSearchBoundary), a project resolved through any other backend (e.g.
+// the module cache, an extracted, read-only tree that's never a git
+// checkout) has no such ancestor, and walking further up would silently
+// attach it to an unrelated repository. ErrRepositoryNotExists is returned
+// if no `.git` is found within the boundary.
+func (p Project) Repo() (*ProjectRepo, error) {
+	root := p.rootPath()
+
+	if isBareRepoDir(root) {
+		repo, err := git.PlainOpen(root)
+		if err != nil {
+			return nil, err
+		}
+
+		return &ProjectRepo{repo: repo}, nil
+	}
+
+	dir, boundary := root, p.gitSearchBoundary(root)
+	for {
+		if _, err := os.Stat(path.Join(dir, ".git")); err == nil {
+			repo, err := git.PlainOpen(dir)
+			if err != nil {
+				return nil, err
+			}
+
+			return &ProjectRepo{repo: repo}, nil
+		}
+
+		if dir == boundary {
+			return nil, git.ErrRepositoryNotExists
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil, git.ErrRepositoryNotExists
+		}
+
+		dir = parent
+	}
+}
+
+// gitSearchBoundary returns the furthest ancestor of root that Repo is
+// allowed to walk up to looking for a `.git` entry. For the legacy
+// GOPATH/src layout, that's the author's own directory, since a project may
+// be a subdirectory of a repository rooted anywhere up to and including the
+// author directory (e.g. Project "github.com/matt3o12/gowork/cmd" living
+// inside the "gowork" checkout). Every other backend resolves a project straight to its own
+// root with no such common ancestor, so the search isn't allowed to leave
+// it.
+func (p Project) gitSearchBoundary(root string) string {
+	if root == p.AbsPath() {
+		return p.Author().AbsPath()
+	}
+
+	return root
+}
+
+// isBareRepoDir reports whether dir itself looks like a bare git repository,
+// i.e. it has HEAD, objects/, and refs/ directly under it rather than a
+// .git subdirectory.
+func isBareRepoDir(dir string) bool {
+	for _, entry := range []string{"HEAD", "objects", "refs"} {
+		if _, err := os.Stat(path.Join(dir, entry)); err != nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Head returns the name of the currently checked out branch and the sha it
+// points at. branch is empty when HEAD is detached.
+func (r *ProjectRepo) Head() (branch, sha string) {
+	head, err := r.repo.Head()
+	if err != nil {
+		return "", ""
+	}
+
+	sha = head.Hash().String()
+	if head.Name().IsBranch() {
+		branch = head.Name().Short()
+	}
+
+	return branch, sha
+}
+
+// Remotes returns every remote configured for the repository, keyed by
+// name, with its first configured URL as the value.
+func (r *ProjectRepo) Remotes() map[string]string {
+	remotes, err := r.repo.Remotes()
+	if err != nil {
+		return nil
+	}
+
+	result := make(map[string]string, len(remotes))
+	for _, remote := range remotes {
+		urls := remote.Config().URLs
+		if len(urls) == 0 {
+			continue
+		}
+
+		result[remote.Config().Name] = urls[0]
+	}
+
+	return result
+}
+
+// IsDirty reports whether the repository's worktree has uncommitted
+// changes. A bare repository is never dirty.
+func (r *ProjectRepo) IsDirty() bool {
+	worktree, err := r.repo.Worktree()
+	if err != nil {
+		return false
+	}
+
+	status, err := worktree.Status()
+	if err != nil {
+		return false
+	}
+
+	return !status.IsClean()
+}
+
+// IsBare reports whether the repository has no working tree.
+func (r *ProjectRepo) IsBare() bool {
+	cfg, err := r.repo.Config()
+	if err != nil {
+		return false
+	}
+
+	return cfg.Core.IsBare
+}
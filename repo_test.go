@@ -0,0 +1,168 @@
+package gowork
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func makeGitProject(t *testing.T) (string, deferFunction) {
+	dir, removeAll := makeProjectTree(t)
+	projectDir := path.Join(dir, "src", "github.com", "matt3o12", "gowork")
+
+	repo, err := git.PlainInit(projectDir, false)
+	require.NoError(t, err)
+
+	_, err = repo.CreateRemote(&config.RemoteConfig{
+		Name: "origin",
+		URLs: []string{"git@github.com:matt3o12/gowork.git"},
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, ioutil.WriteFile(path.Join(projectDir, "README.md"), []byte("hi"), 0644))
+
+	worktree, err := repo.Worktree()
+	require.NoError(t, err)
+
+	_, err = worktree.Add("README.md")
+	require.NoError(t, err)
+
+	_, err = worktree.Commit("initial commit", &git.CommitOptions{
+		Author: &object.Signature{Name: "Test", Email: "test@example.com"},
+	})
+	require.NoError(t, err)
+
+	return dir, removeAll
+}
+
+func TestProjectRepo(t *testing.T) {
+	dir, removeAll := makeGitProject(t)
+	defer removeAll()
+	defer patchEnv("GOPATH", dir)()
+
+	project := Project("github.com/matt3o12/gowork")
+	repo, err := project.Repo()
+	require.NoError(t, err)
+
+	branch, sha := repo.Head()
+	assert.NotEmpty(t, branch)
+	assert.NotEmpty(t, sha)
+
+	assert.Equal(t, map[string]string{"origin": "git@github.com:matt3o12/gowork.git"}, repo.Remotes())
+	assert.False(t, repo.IsDirty())
+	assert.False(t, repo.IsBare())
+
+	readme := path.Join(dir, "src", "github.com", "matt3o12", "gowork", "README.md")
+	require.NoError(t, ioutil.WriteFile(readme, []byte("changed"), 0644))
+	assert.True(t, repo.IsDirty())
+}
+
+func TestProjectRepo_NestedDir(t *testing.T) {
+	dir, removeAll := makeGitProject(t)
+	defer removeAll()
+	defer patchEnv("GOPATH", dir)()
+
+	nested := path.Join(dir, "src", "github.com", "matt3o12", "gowork", "cmd")
+	require.NoError(t, os.MkdirAll(nested, 0777))
+
+	project := NewProject(NewAuthor("github.com", "matt3o12"), "gowork/cmd")
+	repo, err := project.Repo()
+	require.NoError(t, err)
+
+	_, sha := repo.Head()
+	assert.NotEmpty(t, sha)
+}
+
+func TestProjectRepo_Bare(t *testing.T) {
+	dir, removeAll := makeProjectTree(t)
+	defer removeAll()
+	defer patchEnv("GOPATH", dir)()
+
+	projectDir := path.Join(dir, "src", "github.com", "matt3o12", "gowork")
+	require.NoError(t, os.RemoveAll(projectDir))
+
+	_, err := git.PlainInit(projectDir, true)
+	require.NoError(t, err)
+
+	project := Project("github.com/matt3o12/gowork")
+	repo, err := project.Repo()
+	require.NoError(t, err)
+	assert.True(t, repo.IsBare())
+}
+
+func TestProjectRepo_NotAGitRepo(t *testing.T) {
+	defer makeTreeAndEnv(t)()
+
+	_, err := Project("aaa/user/project").Repo()
+	assert.Error(t, err)
+}
+
+func TestProjectRepo_ModCacheDoesNotAttachToAncestorRepo(t *testing.T) {
+	defer makeTreeAndEnv(t)()
+
+	modDir, removeModDir := makeModCacheTree(t)
+	defer removeModDir()
+	defer patchEnv("GOMODCACHE", modDir)()
+
+	// An unrelated repository rooted at the module cache directory, above
+	// the cached module's own root: the module cache itself is never a git
+	// checkout, but an ancestor directory (GOPATH, home, a CI workspace
+	// root, ...) might well be one.
+	_, err := git.PlainInit(modDir, false)
+	require.NoError(t, err)
+
+	project := NewProject(NewAuthor("github.com", "!burnt!sushi"), "toml")
+	_, err = project.Repo()
+	assert.Equal(t, git.ErrRepositoryNotExists, err, "a module-cache-only project must not attach to an unrelated ancestor repo")
+}
+
+func TestFindProjectWithFilters(t *testing.T) {
+	dir, removeAll := makeGitProject(t)
+	defer removeAll()
+	defer patchEnv("GOPATH", dir)()
+
+	findProject := func(filters []ProjectFilter) ([]ProjectMatch, error) {
+		pCh, eCh := makeChs()
+		go FindProjectWithFilters("", true, filters, pCh, eCh)
+		return ProjectChToSlice(pCh, eCh)
+	}
+
+	projs, err := findProject([]ProjectFilter{DirtyFilter()})
+	assert.NoError(t, err)
+	assert.Empty(t, projs, "nothing should be dirty yet")
+
+	readme := path.Join(dir, "src", "github.com", "matt3o12", "gowork", "README.md")
+	require.NoError(t, ioutil.WriteFile(readme, []byte("changed"), 0644))
+
+	projs, err = findProject([]ProjectFilter{DirtyFilter()})
+	assert.NoError(t, err)
+	assert.Equal(t, []ProjectMatch{{Project: "github.com/matt3o12/gowork", Match: MatchProject, GopathEntry: dir}}, projs)
+
+	projs, err = findProject([]ProjectFilter{RemoteFilter("origin", "matt3o12/gowork")})
+	assert.NoError(t, err)
+	assert.Equal(t, []ProjectMatch{{Project: "github.com/matt3o12/gowork", Match: MatchProject, GopathEntry: dir}}, projs)
+
+	projs, err = findProject([]ProjectFilter{RemoteFilter("origin", "no-such-remote")})
+	assert.NoError(t, err)
+	assert.Empty(t, projs)
+
+	project := Project("github.com/matt3o12/gowork")
+	repo, err := project.Repo()
+	require.NoError(t, err)
+	branch, _ := repo.Head()
+
+	projs, err = findProject([]ProjectFilter{BranchFilter(branch)})
+	assert.NoError(t, err)
+	assert.Equal(t, []ProjectMatch{{Project: "github.com/matt3o12/gowork", Match: MatchProject, GopathEntry: dir}}, projs)
+
+	projs, err = findProject([]ProjectFilter{BranchFilter("no-such-branch")})
+	assert.NoError(t, err)
+	assert.Empty(t, projs)
+}
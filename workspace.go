@@ -0,0 +1,121 @@
+package gowork
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/mod/modfile"
+)
+
+// LocalDistributor is the synthetic Distributor that the modules listed in
+// a go.work file are surfaced under, so they show up in AllDistributors and
+// FindProject without being checked out anywhere in GOPATH.
+const LocalDistributor Distributor = "local"
+
+// workspaceAuthor is the only Author LocalDistributor ever has.
+var workspaceAuthor = NewAuthor(LocalDistributor, "workspace")
+
+// findGoWork walks up from dir looking for a go.work file, the same way the
+// go command resolves GOWORK.
+func findGoWork(dir string) string {
+	for {
+		candidate := filepath.Join(dir, "go.work")
+		if _, err := os.Stat(candidate); err == nil {
+			return candidate
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+
+		dir = parent
+	}
+}
+
+// workspaceProjects parses the go.work file GOWORK points to, or the one
+// found by walking up from $PWD if GOWORK isn't set, and returns each of its
+// `use` directives as a Project under LocalDistributor, named after its
+// resolved absolute directory. Like the go command itself, GOWORK=off
+// disables workspace mode entirely.
+func workspaceProjects() ([]Project, error) {
+	goWork := os.Getenv("GOWORK")
+	if goWork == "off" {
+		return nil, nil
+	}
+
+	if goWork == "" {
+		pwd, err := os.Getwd()
+		if err != nil {
+			return nil, err
+		}
+
+		goWork = findGoWork(pwd)
+	}
+
+	if goWork == "" {
+		return nil, nil
+	}
+
+	data, err := ioutil.ReadFile(goWork)
+	if err != nil {
+		return nil, err
+	}
+
+	work, err := modfile.ParseWork(goWork, data, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	workDir := filepath.Dir(goWork)
+
+	var projects []Project
+	for _, use := range work.Use {
+		dir := use.Path
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(workDir, dir)
+		}
+
+		projects = append(projects, NewProject(workspaceAuthor, filepath.ToSlash(dir)))
+	}
+
+	return projects, nil
+}
+
+// localBackend is the DistributorBackend that surfaces go.work's `use`
+// directives (see workspaceProjects).
+type localBackend struct{}
+
+func (localBackend) Authors() ([]Author, error) {
+	projects, err := workspaceProjects()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(projects) == 0 {
+		return nil, nil
+	}
+
+	return []Author{workspaceAuthor}, nil
+}
+
+func (localBackend) Projects(a Author) ([]Project, error) {
+	if a != workspaceAuthor {
+		return nil, nil
+	}
+
+	return workspaceProjects()
+}
+
+func (localBackend) AbsPath(p Project) string {
+	if p.Distributor() != LocalDistributor {
+		return ""
+	}
+
+	return filepath.FromSlash(p.Name())
+}
+
+func init() {
+	RegisterBackend("local", localBackend{})
+}
@@ -0,0 +1,83 @@
+package gowork
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func makeWorkspace(t *testing.T) (string, deferFunction) {
+	dir, err := ioutil.TempDir("", "")
+	require.NoError(t, err)
+
+	require.NoError(t, os.MkdirAll(path.Join(dir, "a"), 0777))
+	require.NoError(t, os.MkdirAll(path.Join(dir, "nested", "b"), 0777))
+
+	goWork := "go 1.21\n\nuse ./a\nuse ./nested/b\n"
+	require.NoError(t, ioutil.WriteFile(path.Join(dir, "go.work"), []byte(goWork), 0644))
+
+	return dir, func() {
+		os.RemoveAll(dir)
+	}
+}
+
+func TestFindGoWork(t *testing.T) {
+	dir, removeAll := makeWorkspace(t)
+	defer removeAll()
+
+	nested := path.Join(dir, "nested", "b")
+	assert.Equal(t, path.Join(dir, "go.work"), findGoWork(nested))
+
+	other, err := ioutil.TempDir("", "")
+	require.NoError(t, err)
+	defer os.RemoveAll(other)
+	assert.Equal(t, "", findGoWork(other))
+}
+
+func TestWorkspaceProjects(t *testing.T) {
+	dir, removeAll := makeWorkspace(t)
+	defer removeAll()
+
+	pwd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(path.Join(dir, "nested", "b")))
+	defer os.Chdir(pwd)
+
+	projects, err := workspaceProjects()
+	require.NoError(t, err)
+
+	expected := []Project{
+		NewProject(workspaceAuthor, filepath.ToSlash(path.Join(dir, "a"))),
+		NewProject(workspaceAuthor, filepath.ToSlash(path.Join(dir, "nested", "b"))),
+	}
+	assert.Equal(t, expected, projects)
+}
+
+func TestLocalBackend(t *testing.T) {
+	dir, removeAll := makeWorkspace(t)
+	defer removeAll()
+
+	pwd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(dir))
+	defer os.Chdir(pwd)
+
+	authors, err := localBackend{}.Authors()
+	require.NoError(t, err)
+	assert.Equal(t, []Author{workspaceAuthor}, authors)
+
+	projects, err := localBackend{}.Projects(workspaceAuthor)
+	require.NoError(t, err)
+	assert.Len(t, projects, 2)
+
+	for _, p := range projects {
+		assert.Equal(t, filepath.FromSlash(p.Name()), localBackend{}.AbsPath(p))
+	}
+
+	assert.Equal(t, "", localBackend{}.AbsPath(Project("github.com/matt3o12/gowork")))
+}